@@ -2,31 +2,64 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/niklas/edenred-cli/internal/edenred"
+	_ "github.com/niklas/edenred-cli/internal/edenred/providers/be"
+	"github.com/niklas/edenred-cli/internal/edenred/providers/fi"
+	_ "github.com/niklas/edenred-cli/internal/edenred/providers/fr"
+	_ "github.com/niklas/edenred-cli/internal/edenred/providers/se"
 )
 
 func main() {
-	username := flag.String("username", "", "Edenred username")
-	password := flag.String("password", "", "Edenred password")
-	format := flag.String("format", "text", "Output format: text or json")
-	baseURL := flag.String("base-url", "", "Override API base URL (for testing)")
-	timeout := flag.Duration("timeout", 15*time.Second, "Request timeout")
-	flag.Parse()
-
-	if err := run(*username, *password, *format, *baseURL, *timeout); err != nil {
+	args := os.Args[1:]
+
+	var err error
+	switch {
+	case len(args) > 0 && args[0] == "serve":
+		err = runServe(args[1:])
+	case len(args) > 0 && args[0] == "transactions":
+		err = runTransactions(args[1:])
+	default:
+		err = runBalances(args)
+	}
+
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
 
-func run(username, password, format, baseURL string, timeout time.Duration) error {
+// runBalances implements the default behaviour: sign in (or reuse a cached
+// session) and print the wallet balances once.
+func runBalances(args []string) error {
+	fs := flag.NewFlagSet("edenred", flag.ExitOnError)
+	username := fs.String("username", "", "Edenred username")
+	password := fs.String("password", "", "Edenred password")
+	format := fs.String("format", "text", "Output format: text or json")
+	baseURL := fs.String("base-url", "", "Override API base URL (for testing)")
+	country := fs.String("country", "fi", "Edenred market to talk to: fi, be, se, or fr")
+	timeout := fs.Duration("timeout", 15*time.Second, "Request timeout")
+	sessionKey := fs.String("session-key", "", "Key used to encrypt the cached session (default: $EDENRED_SESSION_KEY, or a generated key)")
+	recaptchaEndpoint := fs.String("recaptcha-endpoint", "", "Base URL of a 2captcha/anti-captcha compatible solver (enables reCaptcha solving)")
+	recaptchaKey := fs.String("recaptcha-key", "", "Client key for --recaptcha-endpoint")
+	recaptchaSiteKey := fs.String("recaptcha-site-key", "", "Override the Edenred signin reCaptcha site key passed to --recaptcha-endpoint")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	applyRecaptchaSiteKey(*recaptchaSiteKey)
+	return fetchAndPrint(*username, *password, *format, *baseURL, *country, *sessionKey, *recaptchaEndpoint, *recaptchaKey, *timeout)
+}
+
+func fetchAndPrint(username, password, format, baseURL, country, sessionKey, recaptchaEndpoint, recaptchaKey string, timeout time.Duration) error {
 	if username == "" || password == "" {
 		return fmt.Errorf("username and password are required")
 	}
@@ -34,7 +67,16 @@ func run(username, password, format, baseURL string, timeout time.Duration) erro
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	client := edenred.NewClient(nil, baseURL)
+	sessionStore, err := newSessionStore(sessionKey)
+	if err != nil {
+		return fmt.Errorf("set up session cache: %w", err)
+	}
+
+	opts := append([]edenred.Option{edenred.WithSessionStore(sessionStore)}, recaptchaOptions(recaptchaEndpoint, recaptchaKey)...)
+	client, err := edenred.NewClient(country, nil, baseURL, opts...)
+	if err != nil {
+		return err
+	}
 	balances, err := client.FetchBalances(ctx, username, password)
 	if err != nil {
 		return err
@@ -58,3 +100,83 @@ func run(username, password, format, baseURL string, timeout time.Duration) erro
 
 	return nil
 }
+
+// newSessionStore builds the file-backed session cache used to skip
+// signIn on every invocation, deriving its encryption key from
+// sessionKeyFlag, $EDENRED_SESSION_KEY, or a key generated on first use.
+func newSessionStore(sessionKeyFlag string) (*edenred.FileSessionStore, error) {
+	dir, err := configDir()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := resolveSessionKey(sessionKeyFlag, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return edenred.NewFileSessionStore(filepath.Join(dir, "session"), key), nil
+}
+
+func resolveSessionKey(flagValue, dir string) ([]byte, error) {
+	if flagValue != "" {
+		return []byte(flagValue), nil
+	}
+	if env := os.Getenv("EDENRED_SESSION_KEY"); env != "" {
+		return []byte(env), nil
+	}
+	return loadOrGenerateSessionKey(filepath.Join(dir, "session.key"))
+}
+
+// loadOrGenerateSessionKey returns the key stored at path, generating and
+// persisting a random one on first use.
+func loadOrGenerateSessionKey(path string) ([]byte, error) {
+	existing, err := os.ReadFile(path)
+	if err == nil {
+		return existing, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("read session key: %w", err)
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("generate session key: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("create config dir: %w", err)
+	}
+	if err := os.WriteFile(path, key, 0o600); err != nil {
+		return nil, fmt.Errorf("write session key: %w", err)
+	}
+
+	return key, nil
+}
+
+// recaptchaOptions returns the Option needed to solve reCaptcha challenges
+// via endpoint, or none if endpoint is unset (the client then sends an
+// empty token, as it always has).
+func recaptchaOptions(endpoint, key string) []edenred.Option {
+	if endpoint == "" {
+		return nil
+	}
+	return []edenred.Option{edenred.WithRecaptchaSolver(&edenred.HTTPSolver{Endpoint: endpoint, ClientKey: key})}
+}
+
+// applyRecaptchaSiteKey overrides fi.RecaptchaSiteKey when siteKey is set,
+// replacing its built-in placeholder value. Without this, --recaptcha-endpoint
+// solves a reCaptcha task that Edenred will never accept.
+func applyRecaptchaSiteKey(siteKey string) {
+	if siteKey != "" {
+		fi.RecaptchaSiteKey = siteKey
+	}
+}
+
+func configDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "edenred-cli"), nil
+}
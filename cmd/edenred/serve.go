@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/niklas/edenred-cli/internal/edenred"
+)
+
+// runServe starts the HTTP daemon: it polls Edenred for balances on
+// --poll-interval and serves the last successful result over HTTP, so a
+// request is never blocked on an upstream call.
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	username := fs.String("username", "", "Edenred username")
+	password := fs.String("password", "", "Edenred password")
+	baseURL := fs.String("base-url", "", "Override API base URL (for testing)")
+	country := fs.String("country", "fi", "Edenred market to talk to: fi, be, se, or fr")
+	sessionKey := fs.String("session-key", "", "Key used to encrypt the cached session (default: $EDENRED_SESSION_KEY, or a generated key)")
+	recaptchaEndpoint := fs.String("recaptcha-endpoint", "", "Base URL of a 2captcha/anti-captcha compatible solver (enables reCaptcha solving)")
+	recaptchaKey := fs.String("recaptcha-key", "", "Client key for --recaptcha-endpoint")
+	recaptchaSiteKey := fs.String("recaptcha-site-key", "", "Override the Edenred signin reCaptcha site key passed to --recaptcha-endpoint")
+	listen := fs.String("listen", ":9090", "Address to listen on")
+	pollInterval := fs.Duration("poll-interval", time.Hour, "How often to refresh balances from Edenred")
+	timeout := fs.Duration("timeout", 15*time.Second, "Per-request timeout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *username == "" || *password == "" {
+		return fmt.Errorf("username and password are required")
+	}
+
+	applyRecaptchaSiteKey(*recaptchaSiteKey)
+
+	sessionStore, err := newSessionStore(*sessionKey)
+	if err != nil {
+		return fmt.Errorf("set up session cache: %w", err)
+	}
+
+	opts := append([]edenred.Option{edenred.WithSessionStore(sessionStore)}, recaptchaOptions(*recaptchaEndpoint, *recaptchaKey)...)
+	client, err := edenred.NewClient(*country, nil, *baseURL, opts...)
+	if err != nil {
+		return err
+	}
+
+	d := &balanceDaemon{
+		client:   client,
+		username: *username,
+		password: *password,
+		timeout:  *timeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	d.poll(ctx)
+	go d.pollLoop(ctx, *pollInterval)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/balances", d.handleBalances)
+	mux.HandleFunc("/metrics", d.handleMetrics)
+	server := &http.Server{Addr: *listen, Handler: mux}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			serveErr <- err
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	case err := <-serveErr:
+		return err
+	}
+}
+
+// balanceDaemon polls Edenred for balances on an interval and caches the
+// last successful result in memory, so HTTP handlers never block on an
+// upstream call.
+type balanceDaemon struct {
+	client   edenred.Provider
+	username string
+	password string
+	timeout  time.Duration
+
+	mu          sync.Mutex
+	balances    *edenred.Balances
+	lastFetch   time.Time
+	fetchErrors int
+}
+
+func (d *balanceDaemon) pollLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.poll(ctx)
+		}
+	}
+}
+
+func (d *balanceDaemon) poll(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	balances, err := d.client.FetchBalances(ctx, d.username, d.password)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err != nil {
+		d.fetchErrors++
+		fmt.Fprintf(os.Stderr, "poll balances: %v\n", err)
+		return
+	}
+
+	d.balances = balances
+	d.lastFetch = time.Now()
+}
+
+func (d *balanceDaemon) snapshot() (balances *edenred.Balances, lastFetch time.Time, fetchErrors int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.balances, d.lastFetch, d.fetchErrors
+}
+
+func (d *balanceDaemon) handleBalances(w http.ResponseWriter, r *http.Request) {
+	balances, _, _ := d.snapshot()
+	if balances == nil {
+		http.Error(w, "balances not yet available", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]float64{
+		"lunch":  balances.Lunch,
+		"virike": balances.Virike,
+	})
+}
+
+func (d *balanceDaemon) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	balances, lastFetch, fetchErrors := d.snapshot()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	if balances != nil {
+		fmt.Fprintln(w, "# HELP edenred_lunch_balance_eur Lunch wallet balance in euros.")
+		fmt.Fprintln(w, "# TYPE edenred_lunch_balance_eur gauge")
+		fmt.Fprintf(w, "edenred_lunch_balance_eur %f\n", balances.Lunch)
+
+		fmt.Fprintln(w, "# HELP edenred_virike_balance_eur Virike wallet balance in euros.")
+		fmt.Fprintln(w, "# TYPE edenred_virike_balance_eur gauge")
+		fmt.Fprintf(w, "edenred_virike_balance_eur %f\n", balances.Virike)
+	}
+
+	fmt.Fprintln(w, "# HELP edenred_last_fetch_timestamp_seconds Unix time of the last successful balances fetch.")
+	fmt.Fprintln(w, "# TYPE edenred_last_fetch_timestamp_seconds gauge")
+	fmt.Fprintf(w, "edenred_last_fetch_timestamp_seconds %d\n", lastFetch.Unix())
+
+	fmt.Fprintln(w, "# HELP edenred_fetch_errors_total Total number of failed balances fetches.")
+	fmt.Fprintln(w, "# TYPE edenred_fetch_errors_total counter")
+	fmt.Fprintf(w, "edenred_fetch_errors_total %d\n", fetchErrors)
+}
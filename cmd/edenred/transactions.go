@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/niklas/edenred-cli/internal/edenred"
+)
+
+const transactionsDateLayout = "2006-01-02"
+
+// runTransactions implements the transactions subcommand: fetch the
+// transaction history and print it in the requested format.
+func runTransactions(args []string) error {
+	fs := flag.NewFlagSet("transactions", flag.ExitOnError)
+	username := fs.String("username", "", "Edenred username")
+	password := fs.String("password", "", "Edenred password")
+	baseURL := fs.String("base-url", "", "Override API base URL (for testing)")
+	country := fs.String("country", "fi", "Edenred market to talk to: fi, be, se, or fr")
+	sessionKey := fs.String("session-key", "", "Key used to encrypt the cached session (default: $EDENRED_SESSION_KEY, or a generated key)")
+	recaptchaEndpoint := fs.String("recaptcha-endpoint", "", "Base URL of a 2captcha/anti-captcha compatible solver (enables reCaptcha solving)")
+	recaptchaKey := fs.String("recaptcha-key", "", "Client key for --recaptcha-endpoint")
+	recaptchaSiteKey := fs.String("recaptcha-site-key", "", "Override the Edenred signin reCaptcha site key passed to --recaptcha-endpoint")
+	timeout := fs.Duration("timeout", 15*time.Second, "Request timeout")
+	since := fs.String("since", "", "Only include transactions on or after this date (YYYY-MM-DD)")
+	until := fs.String("until", "", "Only include transactions on or before this date (YYYY-MM-DD)")
+	wallet := fs.String("wallet", "all", "Wallet to filter by: main, wellness, or all")
+	format := fs.String("format", "text", "Output format: text, json, csv, or ledger")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *username == "" || *password == "" {
+		return fmt.Errorf("username and password are required")
+	}
+
+	applyRecaptchaSiteKey(*recaptchaSiteKey)
+
+	opts := edenred.TransactionOptions{Wallet: *wallet}
+	if *since != "" {
+		t, err := time.Parse(transactionsDateLayout, *since)
+		if err != nil {
+			return fmt.Errorf("parse --since: %w", err)
+		}
+		opts.Since = t
+	}
+	if *until != "" {
+		t, err := time.Parse(transactionsDateLayout, *until)
+		if err != nil {
+			return fmt.Errorf("parse --until: %w", err)
+		}
+		// Push the bound to the end of the day so the whole --until date is included.
+		opts.Until = t.AddDate(0, 0, 1).Add(-time.Second)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	sessionStore, err := newSessionStore(*sessionKey)
+	if err != nil {
+		return fmt.Errorf("set up session cache: %w", err)
+	}
+
+	clientOpts := append([]edenred.Option{edenred.WithSessionStore(sessionStore)}, recaptchaOptions(*recaptchaEndpoint, *recaptchaKey)...)
+	client, err := edenred.NewClient(*country, nil, *baseURL, clientOpts...)
+	if err != nil {
+		return err
+	}
+	transactions, err := client.FetchTransactions(ctx, *username, *password, opts)
+	if err != nil {
+		return err
+	}
+
+	return printTransactions(os.Stdout, transactions, strings.ToLower(*format))
+}
+
+func printTransactions(w io.Writer, transactions []edenred.Transaction, format string) error {
+	switch format {
+	case "text":
+		for _, tx := range transactions {
+			fmt.Fprintf(w, "%s  %-10s  %-24s  %s %s\n", tx.Timestamp.Format(time.RFC3339), tx.WalletType, tx.Merchant, tx.Amount, tx.Currency)
+		}
+		return nil
+	case "json":
+		return printTransactionsJSON(w, transactions)
+	case "csv":
+		return printTransactionsCSV(w, transactions)
+	case "ledger":
+		printTransactionsLedger(w, transactions)
+		return nil
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+func printTransactionsJSON(w io.Writer, transactions []edenred.Transaction) error {
+	type jsonTransaction struct {
+		Timestamp  time.Time `json:"timestamp"`
+		WalletType string    `json:"walletType"`
+		Merchant   string    `json:"merchant"`
+		Amount     string    `json:"amount"`
+		Currency   string    `json:"currency"`
+	}
+
+	payload := make([]jsonTransaction, len(transactions))
+	for i, tx := range transactions {
+		payload[i] = jsonTransaction{
+			Timestamp:  tx.Timestamp,
+			WalletType: tx.WalletType,
+			Merchant:   tx.Merchant,
+			Amount:     tx.Amount.String(),
+			Currency:   tx.Currency,
+		}
+	}
+
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		return fmt.Errorf("encode json: %w", err)
+	}
+	return nil
+}
+
+func printTransactionsCSV(w io.Writer, transactions []edenred.Transaction) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"timestamp", "wallet", "merchant", "amount", "currency"}); err != nil {
+		return fmt.Errorf("write csv header: %w", err)
+	}
+
+	for _, tx := range transactions {
+		record := []string{
+			tx.Timestamp.Format(time.RFC3339),
+			tx.WalletType,
+			tx.Merchant,
+			tx.Amount.String(),
+			tx.Currency,
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("write csv row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// printTransactionsLedger emits hledger/beancount-compatible double-entry
+// lines so balances can be piped into personal finance tooling.
+func printTransactionsLedger(w io.Writer, transactions []edenred.Transaction) {
+	for _, tx := range transactions {
+		abs := tx.Amount.Abs()
+		fmt.Fprintf(w, "%s %s\n", tx.Timestamp.Format(transactionsDateLayout), tx.Merchant)
+		fmt.Fprintf(w, "    %s  -%s %s\n", ledgerWalletAccount(tx.WalletType), abs, tx.Currency)
+		fmt.Fprintf(w, "    Expenses:Food  %s %s\n\n", abs, tx.Currency)
+	}
+}
+
+func ledgerWalletAccount(walletType string) string {
+	switch walletType {
+	case "wellness":
+		return "Assets:Edenred:Virike"
+	default:
+		return "Assets:Edenred:Lunch"
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/niklas/edenred-cli/internal/edenred"
+)
+
+func TestPrintTransactionsLedgerBalances(t *testing.T) {
+	transactions := []edenred.Transaction{
+		{
+			Timestamp:  time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC),
+			WalletType: "main",
+			Merchant:   "Cafe One",
+			Amount:     edenred.Money{MinorUnits: -1234},
+			Currency:   "EUR",
+		},
+	}
+
+	var buf bytes.Buffer
+	printTransactionsLedger(&buf, transactions)
+
+	out := buf.String()
+	if strings.Contains(out, "--") {
+		t.Fatalf("ledger output contains a double negative: %q", out)
+	}
+	if !strings.Contains(out, "Assets:Edenred:Lunch  -12.34 EUR") {
+		t.Fatalf("expected asset posting to be negated, got: %q", out)
+	}
+	if !strings.Contains(out, "Expenses:Food  12.34 EUR") {
+		t.Fatalf("expected expense posting to be positive, got: %q", out)
+	}
+}
@@ -0,0 +1,33 @@
+package edenred
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// UnexpectedStatusError records a non-200 HTTP response so callers can
+// branch on the status code, e.g. to trigger a session refresh on 401.
+// Provider packages use this so a shared refresh-and-retry helper could
+// recognise it regardless of which provider's HTTP call produced it.
+type UnexpectedStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *UnexpectedStatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+// ReadRespBody reads up to 512 bytes from r for inclusion in an error
+// message, such as the body of an unexpected HTTP response.
+func ReadRespBody(r io.Reader) string {
+	if r == nil {
+		return ""
+	}
+	b, err := io.ReadAll(io.LimitReader(r, 512))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
@@ -0,0 +1,67 @@
+package edenred
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// Money represents a monetary amount as an integer count of minor units
+// (e.g. cents), so arithmetic and formatting never touch floating point.
+// This avoids the rounding that parsing balances through a float64 risks on
+// odd-cent balances.
+type Money struct {
+	MinorUnits int64
+}
+
+// String formats the amount with two decimal places, e.g. "-12.34".
+func (m Money) String() string {
+	minor := m.MinorUnits
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, minor/100, minor%100)
+}
+
+// Float64 returns the amount as a float64, for callers that don't need
+// exact decimal arithmetic.
+func (m Money) Float64() float64 {
+	return float64(m.MinorUnits) / 100
+}
+
+// Abs returns the amount's magnitude, discarding its sign.
+func (m Money) Abs() Money {
+	if m.MinorUnits < 0 {
+		return Money{MinorUnits: -m.MinorUnits}
+	}
+	return m
+}
+
+// ParseMoneyFromJSONNumber parses an amount reported either as an integer
+// count of minor units or as a decimal string, using math/big.Rat so a
+// value like "12.34" is never rounded through a float64 on the way to
+// minor units. Provider packages use this to decode the amounts in their
+// own response shapes.
+func ParseMoneyFromJSONNumber(n json.Number) (Money, error) {
+	if n == "" {
+		return Money{}, nil
+	}
+
+	if minorUnits, err := n.Int64(); err == nil {
+		return Money{MinorUnits: minorUnits}, nil
+	}
+
+	rat, ok := new(big.Rat).SetString(n.String())
+	if !ok {
+		return Money{}, fmt.Errorf("invalid amount %q", n.String())
+	}
+
+	scaled := new(big.Rat).Mul(rat, big.NewRat(100, 1))
+	if !scaled.IsInt() {
+		return Money{}, fmt.Errorf("amount %q is not representable in whole cents", n.String())
+	}
+
+	return Money{MinorUnits: scaled.Num().Int64()}, nil
+}
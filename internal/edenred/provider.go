@@ -0,0 +1,102 @@
+package edenred
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Provider is implemented by a per-country Edenred API backend. Each
+// market has its own base URL, request/response shapes, and auth quirks
+// (some use cookies, others an Authorization: Bearer header), which a
+// Provider implementation owns privately.
+type Provider interface {
+	// Name returns the provider's country code, e.g. "fi".
+	Name() string
+	SignIn(ctx context.Context, username, password string) (*Session, error)
+	FetchBalances(ctx context.Context, username, password string) (*Balances, error)
+	FetchTransactions(ctx context.Context, username, password string, opts TransactionOptions) ([]Transaction, error)
+}
+
+// Config collects the options common to every provider. Provider packages
+// build their client from NewConfig(opts...) rather than poking at opts
+// directly.
+type Config struct {
+	SessionStore    SessionStore
+	RecaptchaSolver RecaptchaSolver
+}
+
+// Option configures optional, provider-agnostic Client behaviour.
+type Option func(*Config)
+
+// WithSessionStore configures a SessionStore that FetchBalances and
+// FetchTransactions use to cache sessions across invocations, skipping
+// SignIn (and its reCaptcha challenge) whenever a valid session is cached.
+func WithSessionStore(store SessionStore) Option {
+	return func(c *Config) {
+		c.SessionStore = store
+	}
+}
+
+// WithRecaptchaSolver configures the RecaptchaSolver used to obtain a
+// reCaptcha response token during sign in. Without this option, providers
+// send an empty token, which is fine until Edenred enforces the challenge.
+func WithRecaptchaSolver(solver RecaptchaSolver) Option {
+	return func(c *Config) {
+		c.RecaptchaSolver = solver
+	}
+}
+
+// NewConfig applies opts over a Config defaulting to NoopSolver. Provider
+// factories call this while building their client.
+func NewConfig(opts ...Option) Config {
+	cfg := Config{RecaptchaSolver: NoopSolver{}}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// Factory constructs a Provider. httpClient and baseURL follow the same
+// conventions the historical NewClient had: a nil httpClient gets a sane
+// default timeout, and an empty baseURL falls back to the provider's own
+// default.
+type Factory func(httpClient *http.Client, baseURL string, opts ...Option) Provider
+
+var registry = map[string]Factory{}
+
+// Register adds a provider factory under country, a lowercase ISO country
+// code such as "fi". Provider packages call this from an init function, so
+// importing a providers/<country> package for its side effect is enough to
+// make that country available to NewClient.
+func Register(country string, factory Factory) {
+	registry[country] = factory
+}
+
+// NewClient returns the Provider registered for country, defaulting to
+// "fi" when country is empty. The caller must have imported the matching
+// providers/<country> package (typically with a blank import) for its
+// registration to have run.
+func NewClient(country string, httpClient *http.Client, baseURL string, opts ...Option) (Provider, error) {
+	if country == "" {
+		country = "fi"
+	}
+
+	factory, ok := registry[country]
+	if !ok {
+		return nil, fmt.Errorf("edenred: unknown provider %q (known: %s)", country, strings.Join(knownProviders(), ", "))
+	}
+
+	return factory(httpClient, baseURL, opts...), nil
+}
+
+func knownProviders() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,61 @@
+// Package be is a scaffold for the edenred.Provider covering Belgium.
+// Belgium is not wired up to a real backend yet; every method except Name
+// reports ErrNotImplemented, so a client from NewClient("be", ...) fails
+// loudly instead of silently returning zero values.
+package be
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/niklas/edenred-cli/internal/edenred"
+)
+
+const defaultBaseURL = "https://api.edenred.be"
+
+// ErrNotImplemented is returned by every Provider method until the
+// Belgium backend is implemented.
+var ErrNotImplemented = errors.New("edenred: be provider not yet implemented")
+
+func init() {
+	edenred.Register("be", newProvider)
+}
+
+// Provider is the (stub) Belgium edenred.Provider.
+type Provider struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func newProvider(httpClient *http.Client, baseURL string, opts ...edenred.Option) edenred.Provider {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 15 * time.Second}
+	}
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	return &Provider{
+		baseURL:    baseURL,
+		httpClient: httpClient,
+	}
+}
+
+// Name returns "be".
+func (p *Provider) Name() string {
+	return "be"
+}
+
+func (p *Provider) SignIn(ctx context.Context, username, password string) (*edenred.Session, error) {
+	return nil, ErrNotImplemented
+}
+
+func (p *Provider) FetchBalances(ctx context.Context, username, password string) (*edenred.Balances, error) {
+	return nil, ErrNotImplemented
+}
+
+func (p *Provider) FetchTransactions(ctx context.Context, username, password string, opts edenred.TransactionOptions) ([]edenred.Transaction, error) {
+	return nil, ErrNotImplemented
+}
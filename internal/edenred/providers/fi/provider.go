@@ -0,0 +1,459 @@
+// Package fi implements the edenred.Provider for Finland: api.myedenred.fi,
+// authenticated via the X-Access-Token / X-Access-Refresh-Token cookies.
+package fi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/niklas/edenred-cli/internal/edenred"
+)
+
+const defaultBaseURL = "https://api.myedenred.fi"
+
+// RecaptchaSiteKey and RecaptchaPageURL identify the reCaptcha v2 widget
+// embedded in the Edenred Finland signin page, as required by the
+// 2captcha/anti-captcha task protocol. They're exported so a caller that
+// knows the real site key (TODO: RecaptchaSiteKey below is a placeholder
+// and will not solve against the live site) can override them before
+// constructing a Provider.
+var (
+	RecaptchaSiteKey = "6LcVoAIAAAAAyour-edenred-signin-site-key"
+	RecaptchaPageURL = "https://www.myedenred.fi/kirjaudu"
+)
+
+func init() {
+	edenred.Register("fi", newProvider)
+}
+
+// Provider is the Finland edenred.Provider.
+type Provider struct {
+	baseURL         string
+	httpClient      *http.Client
+	sessionStore    edenred.SessionStore
+	recaptchaSolver edenred.RecaptchaSolver
+}
+
+func newProvider(httpClient *http.Client, baseURL string, opts ...edenred.Option) edenred.Provider {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 15 * time.Second}
+	}
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	cfg := edenred.NewConfig(opts...)
+
+	return &Provider{
+		baseURL:         strings.TrimRight(baseURL, "/"),
+		httpClient:      httpClient,
+		sessionStore:    cfg.SessionStore,
+		recaptchaSolver: cfg.RecaptchaSolver,
+	}
+}
+
+// Name returns "fi".
+func (p *Provider) Name() string { return "fi" }
+
+// SignIn logs in with the provided credentials and returns the resulting
+// session, ignoring any cached session.
+func (p *Provider) SignIn(ctx context.Context, username, password string) (*edenred.Session, error) {
+	if username == "" || password == "" {
+		return nil, errors.New("username and password are required")
+	}
+
+	result, err := p.signIn(ctx, username, password)
+	if err != nil {
+		return nil, fmt.Errorf("signin failed: %w", err)
+	}
+
+	return &edenred.Session{
+		SessionToken: result.SessionToken,
+		RefreshToken: result.RefreshToken,
+		ExpiresIn:    result.ExpiresIn,
+	}, nil
+}
+
+// FetchBalances logs in (or reuses a cached session) and returns the
+// wallet balances.
+func (p *Provider) FetchBalances(ctx context.Context, username, password string) (*edenred.Balances, error) {
+	if username == "" || password == "" {
+		return nil, errors.New("username and password are required")
+	}
+
+	session, signedIn, err := p.loadOrSignIn(ctx, username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	benefits, refreshed, err := p.getUserBenefitsWithRefresh(ctx, session)
+	if err != nil {
+		return nil, fmt.Errorf("fetching balances failed: %w", err)
+	}
+
+	balances := edenred.Balances{Wallets: map[string]edenred.Money{}}
+	for _, benefit := range benefits {
+		amount, err := edenred.ParseMoneyFromJSONNumber(benefit.Balance)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s balance: %w", benefit.WalletType, err)
+		}
+		balances.Wallets[benefit.WalletType] = amount
+
+		switch benefit.WalletType {
+		case "main":
+			balances.Lunch = amount.Float64()
+		case "wellness":
+			balances.Virike = amount.Float64()
+		}
+	}
+
+	if p.sessionStore != nil && (signedIn || refreshed) {
+		if err := p.sessionStore.Save(*session); err != nil {
+			return nil, fmt.Errorf("save session: %w", err)
+		}
+	}
+
+	return &balances, nil
+}
+
+// FetchTransactions logs in (or reuses a cached session) and returns the
+// transaction history matching opts, following pagination until the API
+// reports no further page.
+func (p *Provider) FetchTransactions(ctx context.Context, username, password string, opts edenred.TransactionOptions) ([]edenred.Transaction, error) {
+	if username == "" || password == "" {
+		return nil, errors.New("username and password are required")
+	}
+
+	session, signedIn, err := p.loadOrSignIn(ctx, username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactions []edenred.Transaction
+	refreshed := false
+	for page := 1; page != 0; {
+		dtos, nextPage, pageRefreshed, err := p.getTransactionsPageWithRefresh(ctx, session, opts, page)
+		if err != nil {
+			return nil, fmt.Errorf("fetching transactions failed: %w", err)
+		}
+		refreshed = refreshed || pageRefreshed
+
+		for _, dto := range dtos {
+			amount, err := edenred.ParseMoneyFromJSONNumber(dto.Amount)
+			if err != nil {
+				return nil, fmt.Errorf("parse transaction amount: %w", err)
+			}
+
+			transactions = append(transactions, edenred.Transaction{
+				Timestamp:  dto.Timestamp,
+				WalletType: dto.WalletType,
+				Merchant:   dto.Merchant,
+				Amount:     amount,
+				Currency:   dto.Currency,
+			})
+		}
+
+		page = nextPage
+	}
+
+	if p.sessionStore != nil && (signedIn || refreshed) {
+		if err := p.sessionStore.Save(*session); err != nil {
+			return nil, fmt.Errorf("save session: %w", err)
+		}
+	}
+
+	return transactions, nil
+}
+
+// loadOrSignIn returns the cached session from p.sessionStore, if one is
+// configured and still valid, falling back to SignIn otherwise. The second
+// return value reports whether SignIn was actually called, so callers know
+// whether the session's tokens (and thus its cache entry) are fresh.
+func (p *Provider) loadOrSignIn(ctx context.Context, username, password string) (*edenred.Session, bool, error) {
+	if p.sessionStore != nil {
+		if cached, err := p.sessionStore.Load(); err == nil && cached != nil {
+			return cached, false, nil
+		}
+	}
+
+	session, err := p.SignIn(ctx, username, password)
+	return session, true, err
+}
+
+type signInRequest struct {
+	Username       string `json:"username"`
+	Password       string `json:"password"`
+	RecaptchaToken string `json:"reCaptchaToken"`
+}
+
+type signInResponse struct {
+	SessionToken string `json:"sessionToken"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int    `json:"expiresIn"`
+	Error        string `json:"error"`
+	ErrorCode    string `json:"errorCode"`
+	FieldName    string `json:"fieldName"`
+}
+
+func (p *Provider) signIn(ctx context.Context, username, password string) (*signInResponse, error) {
+	recaptchaToken, err := p.recaptchaSolver.Solve(ctx, RecaptchaSiteKey, RecaptchaPageURL)
+	if err != nil {
+		return nil, fmt.Errorf("solve recaptcha: %w", err)
+	}
+
+	payload := signInRequest{
+		Username:       username,
+		Password:       password,
+		RecaptchaToken: recaptchaToken,
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/signin", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &edenred.UnexpectedStatusError{StatusCode: resp.StatusCode, Body: edenred.ReadRespBody(resp.Body)}
+	}
+
+	var result signInResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	if result.SessionToken == "" {
+		if result.Error != "" {
+			return nil, errors.New(result.Error)
+		}
+		return nil, errors.New("empty session token in response")
+	}
+
+	return &result, nil
+}
+
+// refreshSession exchanges refreshToken for a renewed session, mirroring
+// the always-refresh flow of oauth2_proxy's Google provider. It mirrors
+// signIn's response shape, but authenticates via the refresh cookie
+// instead of a username/password body.
+func (p *Provider) refreshSession(ctx context.Context, refreshToken string) (*signInResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/refresh", nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.AddCookie(&http.Cookie{Name: "X-Access-Refresh-Token", Value: refreshToken})
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &edenred.UnexpectedStatusError{StatusCode: resp.StatusCode, Body: edenred.ReadRespBody(resp.Body)}
+	}
+
+	var result signInResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	if result.SessionToken == "" {
+		if result.Error != "" {
+			return nil, errors.New(result.Error)
+		}
+		return nil, errors.New("empty session token in response")
+	}
+
+	return &result, nil
+}
+
+type userBenefit struct {
+	CardType           string      `json:"cardType"`
+	WalletType         string      `json:"walletType"`
+	CardStatus         string      `json:"cardStatus"`
+	Balance            json.Number `json:"balance"`
+	MobileAvailable    bool        `json:"mobileAvailable"`
+	MobilePayment      bool        `json:"mobilePaymentEnabled"`
+	ExpectsRenewedCard *string     `json:"expectsRenewedCard"`
+	AccountActive      bool        `json:"accountActive"`
+}
+
+type userBenefitsResponse struct {
+	Benefits []userBenefit `json:"benefits"`
+}
+
+// withSessionRefresh calls fn, which must use session's current tokens,
+// transparently renewing session via refreshSession and retrying fn once
+// if it reports the session was unauthorized. session is updated in place
+// with any renewed tokens, so callers sharing a cached session (balances,
+// transactions) both self-heal from an expired token. The first return
+// value reports whether a refresh happened, so callers know the session's
+// tokens (and thus its cache entry) changed.
+func (p *Provider) withSessionRefresh(ctx context.Context, session *edenred.Session, fn func() error) (bool, error) {
+	err := fn()
+	if err == nil {
+		return false, nil
+	}
+
+	var statusErr *edenred.UnexpectedStatusError
+	if !errors.As(err, &statusErr) || (statusErr.StatusCode != http.StatusUnauthorized && statusErr.StatusCode != http.StatusForbidden) {
+		return false, err
+	}
+
+	renewed, err := p.refreshSession(ctx, session.RefreshToken)
+	if err != nil {
+		return false, fmt.Errorf("refresh session: %w", err)
+	}
+
+	session.SessionToken = renewed.SessionToken
+	session.RefreshToken = renewed.RefreshToken
+	session.ExpiresIn = renewed.ExpiresIn
+
+	return true, fn()
+}
+
+// getUserBenefitsWithRefresh calls getUserBenefits, transparently renewing
+// session via withSessionRefresh and retrying once if the call comes back
+// unauthorized.
+func (p *Provider) getUserBenefitsWithRefresh(ctx context.Context, session *edenred.Session) ([]userBenefit, bool, error) {
+	var benefits []userBenefit
+	refreshed, err := p.withSessionRefresh(ctx, session, func() error {
+		var err error
+		benefits, err = p.getUserBenefits(ctx, session.SessionToken, session.RefreshToken)
+		return err
+	})
+	return benefits, refreshed, err
+}
+
+func (p *Provider) getUserBenefits(ctx context.Context, sessionToken, refreshToken string) ([]userBenefit, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/users/me/user-benefits", nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if sessionToken != "" {
+		req.AddCookie(&http.Cookie{Name: "X-Access-Token", Value: sessionToken})
+	}
+	if refreshToken != "" {
+		req.AddCookie(&http.Cookie{Name: "X-Access-Refresh-Token", Value: refreshToken})
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &edenred.UnexpectedStatusError{StatusCode: resp.StatusCode, Body: edenred.ReadRespBody(resp.Body)}
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+
+	var result userBenefitsResponse
+	if err := dec.Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	return result.Benefits, nil
+}
+
+type transactionDTO struct {
+	Timestamp  time.Time   `json:"timestamp"`
+	WalletType string      `json:"walletType"`
+	Merchant   string      `json:"merchant"`
+	Amount     json.Number `json:"amount"`
+	Currency   string      `json:"currency"`
+}
+
+type transactionsResponse struct {
+	Transactions []transactionDTO `json:"transactions"`
+	NextPage     int              `json:"nextPage"`
+}
+
+func (p *Provider) getTransactionsPage(ctx context.Context, session *edenred.Session, opts edenred.TransactionOptions, page int) ([]transactionDTO, int, error) {
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	if !opts.Since.IsZero() {
+		query.Set("since", opts.Since.Format(time.RFC3339))
+	}
+	if !opts.Until.IsZero() {
+		query.Set("until", opts.Until.Format(time.RFC3339))
+	}
+	if opts.Wallet != "" && opts.Wallet != "all" {
+		query.Set("walletType", opts.Wallet)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/users/me/transactions?"+query.Encode(), nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if session.SessionToken != "" {
+		req.AddCookie(&http.Cookie{Name: "X-Access-Token", Value: session.SessionToken})
+	}
+	if session.RefreshToken != "" {
+		req.AddCookie(&http.Cookie{Name: "X-Access-Refresh-Token", Value: session.RefreshToken})
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, &edenred.UnexpectedStatusError{StatusCode: resp.StatusCode, Body: edenred.ReadRespBody(resp.Body)}
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+
+	var result transactionsResponse
+	if err := dec.Decode(&result); err != nil {
+		return nil, 0, fmt.Errorf("decode response: %w", err)
+	}
+
+	return result.Transactions, result.NextPage, nil
+}
+
+// getTransactionsPageWithRefresh calls getTransactionsPage, transparently
+// renewing session via withSessionRefresh and retrying once if the call
+// comes back unauthorized.
+func (p *Provider) getTransactionsPageWithRefresh(ctx context.Context, session *edenred.Session, opts edenred.TransactionOptions, page int) ([]transactionDTO, int, bool, error) {
+	var dtos []transactionDTO
+	var nextPage int
+	refreshed, err := p.withSessionRefresh(ctx, session, func() error {
+		var err error
+		dtos, nextPage, err = p.getTransactionsPage(ctx, session, opts, page)
+		return err
+	})
+	return dtos, nextPage, refreshed, err
+}
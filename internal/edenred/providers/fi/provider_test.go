@@ -0,0 +1,438 @@
+package fi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/niklas/edenred-cli/internal/edenred"
+	_ "github.com/niklas/edenred-cli/internal/edenred/providers/fi"
+	"github.com/niklas/edenred-cli/internal/edenred/providers/providertest"
+)
+
+func TestFetchBalancesSuccess(t *testing.T) {
+	const (
+		expectedUser       = "test-user"
+		expectedPass       = "test-password"
+		sessionToken       = "session-token"
+		refreshToken       = "refresh-token"
+		lunchBalanceCents  = 6850
+		virikeBalanceCents = 12345
+		lunchBalance       = float64(lunchBalanceCents) / 100
+		virikeBal          = float64(virikeBalanceCents) / 100
+	)
+
+	server := providertest.NewServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/signin":
+			if r.Method != http.MethodPost {
+				t.Fatalf("unexpected method %s for /signin", r.Method)
+			}
+			var reqBody map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+				t.Fatalf("decode signin request: %v", err)
+			}
+			if got := reqBody["username"]; got != expectedUser {
+				t.Fatalf("unexpected username %q", got)
+			}
+			if got := reqBody["password"]; got != expectedPass {
+				t.Fatalf("unexpected password %q", got)
+			}
+			providertest.WriteJSON(t, w, map[string]any{
+				"sessionToken": sessionToken,
+				"refreshToken": refreshToken,
+			})
+		case "/users/me/user-benefits":
+			tokenCookie, err := r.Cookie("X-Access-Token")
+			if err != nil {
+				t.Fatalf("expected X-Access-Token cookie: %v", err)
+			}
+			if tokenCookie.Value != sessionToken {
+				t.Fatalf("unexpected X-Access-Token value %q", tokenCookie.Value)
+			}
+			refreshCookie, err := r.Cookie("X-Access-Refresh-Token")
+			if err != nil {
+				t.Fatalf("expected X-Access-Refresh-Token cookie: %v", err)
+			}
+			if refreshCookie.Value != refreshToken {
+				t.Fatalf("unexpected X-Access-Refresh-Token value %q", refreshCookie.Value)
+			}
+			providertest.WriteJSON(t, w, map[string]any{
+				"benefits": []map[string]any{
+					{"walletType": "main", "balance": lunchBalanceCents},
+					{"walletType": "wellness", "balance": virikeBalanceCents},
+				},
+			})
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	provider, err := edenred.NewClient("fi", server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	balances, err := provider.FetchBalances(context.Background(), expectedUser, expectedPass)
+	if err != nil {
+		t.Fatalf("fetch balances: %v", err)
+	}
+
+	if diff := balances.Lunch - lunchBalance; diff < -1e-9 || diff > 1e-9 {
+		t.Fatalf("unexpected lunch balance %.2f", balances.Lunch)
+	}
+	if diff := balances.Virike - virikeBal; diff < -1e-9 || diff > 1e-9 {
+		t.Fatalf("unexpected virike balance %.2f", balances.Virike)
+	}
+	if got := balances.Wallets["main"].String(); got != "68.50" {
+		t.Fatalf("unexpected main wallet amount %q", got)
+	}
+	if got := balances.Wallets["wellness"].String(); got != "123.45" {
+		t.Fatalf("unexpected wellness wallet amount %q", got)
+	}
+}
+
+func TestFetchBalancesSigninError(t *testing.T) {
+	server := providertest.NewServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/signin" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("invalid credentials"))
+	})
+
+	provider, err := edenred.NewClient("fi", server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	_, err = provider.FetchBalances(context.Background(), "user", "badpass")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if want := "signin failed"; !strings.Contains(err.Error(), want) {
+		t.Fatalf("expected error containing %q, got %v", want, err)
+	}
+}
+
+func TestFetchBalancesHandlesDecimalBalance(t *testing.T) {
+	const (
+		expectedUser = "decimal-user"
+		expectedPass = "decimal-password"
+		sessionToken = "token"
+		refreshToken = "refresh"
+		lunchBalance = 12.34
+	)
+
+	server := providertest.NewServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/signin":
+			providertest.WriteJSON(t, w, map[string]any{
+				"sessionToken": sessionToken,
+				"refreshToken": refreshToken,
+			})
+		case "/users/me/user-benefits":
+			providertest.WriteJSON(t, w, map[string]any{
+				"benefits": []map[string]any{
+					{"walletType": "main", "balance": lunchBalance},
+				},
+			})
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	provider, err := edenred.NewClient("fi", server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	balances, err := provider.FetchBalances(context.Background(), expectedUser, expectedPass)
+	if err != nil {
+		t.Fatalf("fetch balances: %v", err)
+	}
+
+	if diff := balances.Lunch - lunchBalance; diff < -1e-9 || diff > 1e-9 {
+		t.Fatalf("unexpected lunch balance %.2f", balances.Lunch)
+	}
+}
+
+func TestFetchBalancesRefreshesExpiredSessionOnce(t *testing.T) {
+	const (
+		expectedUser      = "test-user"
+		expectedPass      = "test-password"
+		staleToken        = "stale-token"
+		refreshedToken    = "refreshed-token"
+		refreshedRefresh  = "refreshed-refresh-token"
+		lunchBalanceCents = 6850
+	)
+
+	benefitRequests := 0
+
+	server := providertest.NewServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/signin":
+			providertest.WriteJSON(t, w, map[string]any{
+				"sessionToken": staleToken,
+				"refreshToken": "stale-refresh-token",
+			})
+		case "/refresh":
+			if r.Method != http.MethodPost {
+				t.Fatalf("unexpected method %s for /refresh", r.Method)
+			}
+			cookie, err := r.Cookie("X-Access-Refresh-Token")
+			if err != nil || cookie.Value != "stale-refresh-token" {
+				t.Fatalf("expected stale refresh cookie, got %v %v", cookie, err)
+			}
+			providertest.WriteJSON(t, w, map[string]any{
+				"sessionToken": refreshedToken,
+				"refreshToken": refreshedRefresh,
+			})
+		case "/users/me/user-benefits":
+			benefitRequests++
+			tokenCookie, err := r.Cookie("X-Access-Token")
+			if err != nil {
+				t.Fatalf("expected X-Access-Token cookie: %v", err)
+			}
+			if tokenCookie.Value == staleToken {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte("expired"))
+				return
+			}
+			if tokenCookie.Value != refreshedToken {
+				t.Fatalf("unexpected X-Access-Token value %q", tokenCookie.Value)
+			}
+			providertest.WriteJSON(t, w, map[string]any{
+				"benefits": []map[string]any{
+					{"walletType": "main", "balance": lunchBalanceCents},
+				},
+			})
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	store := &providertest.MemoryStore{}
+	provider, err := edenred.NewClient("fi", server.Client(), server.URL, edenred.WithSessionStore(store))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	balances, err := provider.FetchBalances(context.Background(), expectedUser, expectedPass)
+	if err != nil {
+		t.Fatalf("fetch balances: %v", err)
+	}
+	if benefitRequests != 2 {
+		t.Fatalf("expected one retry after refresh, got %d user-benefits requests", benefitRequests)
+	}
+	if diff := balances.Lunch - float64(lunchBalanceCents)/100; diff < -1e-9 || diff > 1e-9 {
+		t.Fatalf("unexpected lunch balance %.2f", balances.Lunch)
+	}
+
+	saved, err := store.Load()
+	if err != nil {
+		t.Fatalf("load saved session: %v", err)
+	}
+	if saved == nil || saved.SessionToken != refreshedToken || saved.RefreshToken != refreshedRefresh {
+		t.Fatalf("expected saved session to hold refreshed tokens, got %+v", saved)
+	}
+}
+
+func TestFetchBalancesDoesNotResaveReusedSession(t *testing.T) {
+	const (
+		expectedUser      = "test-user"
+		expectedPass      = "test-password"
+		cachedToken       = "cached-token"
+		cachedRefresh     = "cached-refresh-token"
+		lunchBalanceCents = 6850
+	)
+
+	server := providertest.NewServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/signin":
+			t.Fatal("signin should not be called when a valid session is cached")
+		case "/users/me/user-benefits":
+			tokenCookie, err := r.Cookie("X-Access-Token")
+			if err != nil || tokenCookie.Value != cachedToken {
+				t.Fatalf("expected cached X-Access-Token cookie, got %v %v", tokenCookie, err)
+			}
+			providertest.WriteJSON(t, w, map[string]any{
+				"benefits": []map[string]any{
+					{"walletType": "main", "balance": lunchBalanceCents},
+				},
+			})
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	store := &providertest.MemoryStore{}
+	store.Seed(edenred.Session{SessionToken: cachedToken, RefreshToken: cachedRefresh, ExpiresIn: 3600})
+
+	provider, err := edenred.NewClient("fi", server.Client(), server.URL, edenred.WithSessionStore(store))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	if _, err := provider.FetchBalances(context.Background(), expectedUser, expectedPass); err != nil {
+		t.Fatalf("fetch balances: %v", err)
+	}
+
+	if got := store.SaveCount(); got != 0 {
+		t.Fatalf("expected a reused session not to be re-saved, got %d saves", got)
+	}
+}
+
+func TestFetchTransactionsPaginatesAndParsesAmounts(t *testing.T) {
+	const (
+		expectedUser = "test-user"
+		expectedPass = "test-password"
+		sessionToken = "session-token"
+		refreshToken = "refresh-token"
+	)
+
+	server := providertest.NewServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/signin":
+			providertest.WriteJSON(t, w, map[string]any{
+				"sessionToken": sessionToken,
+				"refreshToken": refreshToken,
+			})
+		case "/users/me/transactions":
+			tokenCookie, err := r.Cookie("X-Access-Token")
+			if err != nil || tokenCookie.Value != sessionToken {
+				t.Fatalf("expected X-Access-Token cookie, got %v %v", tokenCookie, err)
+			}
+
+			switch r.URL.Query().Get("page") {
+			case "1":
+				providertest.WriteJSON(t, w, map[string]any{
+					"transactions": []map[string]any{
+						{
+							"timestamp":  "2026-01-02T10:00:00Z",
+							"walletType": "main",
+							"merchant":   "Cafe One",
+							"amount":     -12.34,
+							"currency":   "EUR",
+						},
+					},
+					"nextPage": 2,
+				})
+			case "2":
+				providertest.WriteJSON(t, w, map[string]any{
+					"transactions": []map[string]any{
+						{
+							"timestamp":  "2026-01-03T10:00:00Z",
+							"walletType": "wellness",
+							"merchant":   "Gym",
+							"amount":     -685,
+							"currency":   "EUR",
+						},
+					},
+					"nextPage": 0,
+				})
+			default:
+				t.Fatalf("unexpected page %q", r.URL.Query().Get("page"))
+			}
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	provider, err := edenred.NewClient("fi", server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	transactions, err := provider.FetchTransactions(context.Background(), expectedUser, expectedPass, edenred.TransactionOptions{})
+	if err != nil {
+		t.Fatalf("fetch transactions: %v", err)
+	}
+
+	if len(transactions) != 2 {
+		t.Fatalf("expected 2 transactions across pages, got %d", len(transactions))
+	}
+	if got := transactions[0].Amount.String(); got != "-12.34" {
+		t.Fatalf("unexpected amount for decimal transaction: %q", got)
+	}
+	if got := transactions[1].Amount.String(); got != "-6.85" {
+		t.Fatalf("unexpected amount for minor-units transaction: %q", got)
+	}
+}
+
+func TestFetchTransactionsRefreshesExpiredSessionOnce(t *testing.T) {
+	const (
+		expectedUser     = "test-user"
+		expectedPass     = "test-password"
+		staleToken       = "stale-token"
+		refreshedToken   = "refreshed-token"
+		refreshedRefresh = "refreshed-refresh-token"
+	)
+
+	transactionRequests := 0
+
+	server := providertest.NewServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/signin":
+			providertest.WriteJSON(t, w, map[string]any{
+				"sessionToken": staleToken,
+				"refreshToken": "stale-refresh-token",
+			})
+		case "/refresh":
+			cookie, err := r.Cookie("X-Access-Refresh-Token")
+			if err != nil || cookie.Value != "stale-refresh-token" {
+				t.Fatalf("expected stale refresh cookie, got %v %v", cookie, err)
+			}
+			providertest.WriteJSON(t, w, map[string]any{
+				"sessionToken": refreshedToken,
+				"refreshToken": refreshedRefresh,
+			})
+		case "/users/me/transactions":
+			transactionRequests++
+			tokenCookie, err := r.Cookie("X-Access-Token")
+			if err != nil {
+				t.Fatalf("expected X-Access-Token cookie: %v", err)
+			}
+			if tokenCookie.Value == staleToken {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte("expired"))
+				return
+			}
+			if tokenCookie.Value != refreshedToken {
+				t.Fatalf("unexpected X-Access-Token value %q", tokenCookie.Value)
+			}
+			providertest.WriteJSON(t, w, map[string]any{
+				"transactions": []map[string]any{
+					{
+						"timestamp":  "2026-01-02T10:00:00Z",
+						"walletType": "main",
+						"merchant":   "Cafe One",
+						"amount":     -12.34,
+						"currency":   "EUR",
+					},
+				},
+				"nextPage": 0,
+			})
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	provider, err := edenred.NewClient("fi", server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	transactions, err := provider.FetchTransactions(context.Background(), expectedUser, expectedPass, edenred.TransactionOptions{})
+	if err != nil {
+		t.Fatalf("fetch transactions: %v", err)
+	}
+	if transactionRequests != 2 {
+		t.Fatalf("expected one retry after refresh, got %d transactions requests", transactionRequests)
+	}
+	if len(transactions) != 1 {
+		t.Fatalf("expected 1 transaction, got %d", len(transactions))
+	}
+}
@@ -0,0 +1,61 @@
+// Package fr is a scaffold for the edenred.Provider covering France.
+// France is not wired up to a real backend yet; every method except Name
+// reports ErrNotImplemented, so a client from NewClient("fr", ...) fails
+// loudly instead of silently returning zero values.
+package fr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/niklas/edenred-cli/internal/edenred"
+)
+
+const defaultBaseURL = "https://api.edenred.fr"
+
+// ErrNotImplemented is returned by every Provider method until the
+// France backend is implemented.
+var ErrNotImplemented = errors.New("edenred: fr provider not yet implemented")
+
+func init() {
+	edenred.Register("fr", newProvider)
+}
+
+// Provider is the (stub) France edenred.Provider.
+type Provider struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func newProvider(httpClient *http.Client, baseURL string, opts ...edenred.Option) edenred.Provider {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 15 * time.Second}
+	}
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	return &Provider{
+		baseURL:    baseURL,
+		httpClient: httpClient,
+	}
+}
+
+// Name returns "fr".
+func (p *Provider) Name() string {
+	return "fr"
+}
+
+func (p *Provider) SignIn(ctx context.Context, username, password string) (*edenred.Session, error) {
+	return nil, ErrNotImplemented
+}
+
+func (p *Provider) FetchBalances(ctx context.Context, username, password string) (*edenred.Balances, error) {
+	return nil, ErrNotImplemented
+}
+
+func (p *Provider) FetchTransactions(ctx context.Context, username, password string, opts edenred.TransactionOptions) ([]edenred.Transaction, error) {
+	return nil, ErrNotImplemented
+}
@@ -0,0 +1,72 @@
+// Package providertest provides shared test scaffolding for
+// edenred.Provider implementations: an httptest harness and an in-memory
+// SessionStore, so each provider package doesn't reinvent them.
+package providertest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/niklas/edenred-cli/internal/edenred"
+)
+
+// NewServer starts an httptest.Server running handler and registers it to
+// close when the test completes.
+func NewServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// WriteJSON encodes v as the response body and sets the JSON content type.
+func WriteJSON(t *testing.T, w http.ResponseWriter, v any) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Fatalf("encode json response: %v", err)
+	}
+}
+
+// MemoryStore is an in-memory edenred.SessionStore for tests that need to
+// observe what a provider saved after a fetch (e.g. renewed tokens, or
+// whether a reused session was needlessly re-saved).
+type MemoryStore struct {
+	mu        sync.Mutex
+	session   *edenred.Session
+	saveCount int
+}
+
+// Load returns the last session saved, or nil if none has been yet.
+func (m *MemoryStore) Load() (*edenred.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.session, nil
+}
+
+// Save records session, overwriting any previous value.
+func (m *MemoryStore) Save(session edenred.Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.session = &session
+	m.saveCount++
+	return nil
+}
+
+// SaveCount returns how many times Save has been called.
+func (m *MemoryStore) SaveCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.saveCount
+}
+
+// Seed pre-populates the store with session, as if it had been saved by an
+// earlier invocation.
+func (m *MemoryStore) Seed(session edenred.Session) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.session = &session
+}
@@ -0,0 +1,61 @@
+// Package se is a scaffold for the edenred.Provider covering Sweden.
+// Sweden is not wired up to a real backend yet; every method except Name
+// reports ErrNotImplemented, so a client from NewClient("se", ...) fails
+// loudly instead of silently returning zero values.
+package se
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/niklas/edenred-cli/internal/edenred"
+)
+
+const defaultBaseURL = "https://api.edenred.se"
+
+// ErrNotImplemented is returned by every Provider method until the
+// Sweden backend is implemented.
+var ErrNotImplemented = errors.New("edenred: se provider not yet implemented")
+
+func init() {
+	edenred.Register("se", newProvider)
+}
+
+// Provider is the (stub) Sweden edenred.Provider.
+type Provider struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func newProvider(httpClient *http.Client, baseURL string, opts ...edenred.Option) edenred.Provider {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 15 * time.Second}
+	}
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	return &Provider{
+		baseURL:    baseURL,
+		httpClient: httpClient,
+	}
+}
+
+// Name returns "se".
+func (p *Provider) Name() string {
+	return "se"
+}
+
+func (p *Provider) SignIn(ctx context.Context, username, password string) (*edenred.Session, error) {
+	return nil, ErrNotImplemented
+}
+
+func (p *Provider) FetchBalances(ctx context.Context, username, password string) (*edenred.Balances, error) {
+	return nil, ErrNotImplemented
+}
+
+func (p *Provider) FetchTransactions(ctx context.Context, username, password string, opts edenred.TransactionOptions) ([]edenred.Transaction, error) {
+	return nil, ErrNotImplemented
+}
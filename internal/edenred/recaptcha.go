@@ -0,0 +1,184 @@
+package edenred
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RecaptchaSolver obtains a reCaptcha response token for the signin
+// challenge identified by siteKey and pageURL.
+type RecaptchaSolver interface {
+	Solve(ctx context.Context, siteKey, pageURL string) (string, error)
+}
+
+// NoopSolver never solves a challenge; it reproduces the client's
+// historical behaviour of sending an empty reCaptcha token, which is fine
+// as long as Edenred doesn't enforce the challenge on /signin.
+type NoopSolver struct{}
+
+// Solve always returns an empty token.
+func (NoopSolver) Solve(ctx context.Context, siteKey, pageURL string) (string, error) {
+	return "", nil
+}
+
+// HTTPSolver solves reCaptcha challenges via a 2captcha/anti-captcha
+// compatible HTTP service: it submits a createTask request and polls
+// getTaskResult until the task is ready.
+type HTTPSolver struct {
+	// Endpoint is the base URL of the solving service, e.g.
+	// "https://api.2captcha.com". createTask and getTaskResult are
+	// POSTed beneath it.
+	Endpoint string
+	// ClientKey authenticates with the solving service.
+	ClientKey string
+
+	// HTTPClient is used for requests to Endpoint. Defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client
+	// PollInterval controls how often getTaskResult is polled while a
+	// task is still processing. Defaults to 5s.
+	PollInterval time.Duration
+}
+
+type recaptchaTask struct {
+	Type       string `json:"type"`
+	WebsiteURL string `json:"websiteURL"`
+	WebsiteKey string `json:"websiteKey"`
+}
+
+type createTaskRequest struct {
+	ClientKey string        `json:"clientKey"`
+	Task      recaptchaTask `json:"task"`
+}
+
+type createTaskResponse struct {
+	ErrorID   int    `json:"errorId"`
+	ErrorCode string `json:"errorCode"`
+	TaskID    int64  `json:"taskId"`
+}
+
+type getTaskResultRequest struct {
+	ClientKey string `json:"clientKey"`
+	TaskID    int64  `json:"taskId"`
+}
+
+type getTaskResultResponse struct {
+	ErrorID   int    `json:"errorId"`
+	ErrorCode string `json:"errorCode"`
+	Status    string `json:"status"`
+	Solution  struct {
+		GRecaptchaResponse string `json:"gRecaptchaResponse"`
+	} `json:"solution"`
+}
+
+// Solve implements RecaptchaSolver.
+func (s *HTTPSolver) Solve(ctx context.Context, siteKey, pageURL string) (string, error) {
+	taskID, err := s.createTask(ctx, siteKey, pageURL)
+	if err != nil {
+		return "", fmt.Errorf("create recaptcha task: %w", err)
+	}
+
+	for {
+		result, err := s.getTaskResult(ctx, taskID)
+		if err != nil {
+			return "", fmt.Errorf("poll recaptcha task: %w", err)
+		}
+
+		switch result.Status {
+		case "ready":
+			return result.Solution.GRecaptchaResponse, nil
+		case "processing":
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-time.After(s.pollInterval()):
+			}
+		default:
+			return "", fmt.Errorf("unexpected recaptcha task status %q", result.Status)
+		}
+	}
+}
+
+func (s *HTTPSolver) createTask(ctx context.Context, siteKey, pageURL string) (int64, error) {
+	payload := createTaskRequest{
+		ClientKey: s.ClientKey,
+		Task: recaptchaTask{
+			Type:       "RecaptchaV2TaskProxyless",
+			WebsiteURL: pageURL,
+			WebsiteKey: siteKey,
+		},
+	}
+
+	var result createTaskResponse
+	if err := s.post(ctx, "/createTask", payload, &result); err != nil {
+		return 0, err
+	}
+	if result.ErrorID != 0 {
+		return 0, fmt.Errorf("solver error %s", result.ErrorCode)
+	}
+
+	return result.TaskID, nil
+}
+
+func (s *HTTPSolver) getTaskResult(ctx context.Context, taskID int64) (*getTaskResultResponse, error) {
+	payload := getTaskResultRequest{ClientKey: s.ClientKey, TaskID: taskID}
+
+	var result getTaskResultResponse
+	if err := s.post(ctx, "/getTaskResult", payload, &result); err != nil {
+		return nil, err
+	}
+	if result.ErrorID != 0 {
+		return nil, fmt.Errorf("solver error %s", result.ErrorCode)
+	}
+
+	return &result, nil
+}
+
+func (s *HTTPSolver) post(ctx context.Context, path string, payload, out any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(s.Endpoint, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &UnexpectedStatusError{StatusCode: resp.StatusCode, Body: ReadRespBody(resp.Body)}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
+
+func (s *HTTPSolver) httpClient() *http.Client {
+	if s.HTTPClient != nil {
+		return s.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (s *HTTPSolver) pollInterval() time.Duration {
+	if s.PollInterval > 0 {
+		return s.PollInterval
+	}
+	return 5 * time.Second
+}
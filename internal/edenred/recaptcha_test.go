@@ -0,0 +1,79 @@
+package edenred_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/niklas/edenred-cli/internal/edenred"
+)
+
+func TestHTTPSolverPollsUntilReady(t *testing.T) {
+	const (
+		clientKey = "test-client-key"
+		taskID    = 42
+		token     = "g-recaptcha-response"
+	)
+
+	getTaskResultCalls := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/createTask":
+			var req map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode createTask request: %v", err)
+			}
+			if req["clientKey"] != clientKey {
+				t.Fatalf("unexpected clientKey %v", req["clientKey"])
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"taskId": taskID})
+		case "/getTaskResult":
+			getTaskResultCalls++
+			w.Header().Set("Content-Type", "application/json")
+			if getTaskResultCalls < 2 {
+				_ = json.NewEncoder(w).Encode(map[string]any{"status": "processing"})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"status":   "ready",
+				"solution": map[string]any{"gRecaptchaResponse": token},
+			})
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	solver := &edenred.HTTPSolver{
+		Endpoint:     server.URL,
+		ClientKey:    clientKey,
+		HTTPClient:   server.Client(),
+		PollInterval: time.Millisecond,
+	}
+
+	got, err := solver.Solve(context.Background(), "site-key", "https://example.com")
+	if err != nil {
+		t.Fatalf("solve: %v", err)
+	}
+	if got != token {
+		t.Fatalf("unexpected token %q", got)
+	}
+	if getTaskResultCalls < 2 {
+		t.Fatalf("expected at least 2 getTaskResult calls, got %d", getTaskResultCalls)
+	}
+}
+
+func TestNoopSolverReturnsEmptyToken(t *testing.T) {
+	got, err := (edenred.NoopSolver{}).Solve(context.Background(), "site-key", "https://example.com")
+	if err != nil {
+		t.Fatalf("solve: %v", err)
+	}
+	if got != "" {
+		t.Fatalf("expected empty token, got %q", got)
+	}
+}
@@ -0,0 +1,182 @@
+package edenred
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SessionStore persists a signed-in Session between CLI invocations so
+// FetchBalances can skip the /signin endpoint, and the reCaptcha friction
+// it triggers, as long as a valid session is already cached.
+type SessionStore interface {
+	// Load returns the cached session, or nil if none is stored or the
+	// stored one is no longer usable (expired, corrupt, or tampered with).
+	Load() (*Session, error)
+	// Save persists the session, overwriting any previous value.
+	Save(Session) error
+}
+
+// Session is the subset of a signIn response worth caching across
+// invocations.
+type Session struct {
+	SessionToken string `json:"sessionToken"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int    `json:"expiresIn"`
+}
+
+// FileSessionStore persists a Session to a single file, encrypted at rest.
+// It follows the oauth2_proxy cookie scheme: the token blob is AES-CFB
+// encrypted, a Unix timestamp is appended, the pair is signed with
+// HMAC-SHA256, and each part is base64-encoded and joined with "|".
+type FileSessionStore struct {
+	path string
+	key  [32]byte
+}
+
+// NewFileSessionStore returns a SessionStore backed by path, deriving its
+// encryption and signing key from seed via SHA-256.
+func NewFileSessionStore(path string, seed []byte) *FileSessionStore {
+	return &FileSessionStore{
+		path: path,
+		key:  sha256.Sum256(seed),
+	}
+}
+
+// Load reads and verifies the session file. It returns a nil session, with
+// no error, when the file does not exist yet.
+func (s *FileSessionStore) Load() (*Session, error) {
+	raw, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read session file: %w", err)
+	}
+
+	parts := strings.Split(strings.TrimSpace(string(raw)), "|")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed session file")
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("decode ciphertext: %w", err)
+	}
+	tsRaw, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("decode timestamp: %w", err)
+	}
+	sig, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("decode signature: %w", err)
+	}
+
+	if !hmac.Equal(sig, s.sign(ciphertext, tsRaw)) {
+		return nil, errors.New("session signature mismatch")
+	}
+
+	issuedAt, err := strconv.ParseInt(string(tsRaw), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse session timestamp: %w", err)
+	}
+
+	plaintext, err := s.decrypt(ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt session: %w", err)
+	}
+
+	var session Session
+	if err := json.Unmarshal(plaintext, &session); err != nil {
+		return nil, fmt.Errorf("decode session: %w", err)
+	}
+
+	if time.Now().Unix() >= issuedAt+int64(session.ExpiresIn) {
+		return nil, errors.New("session expired")
+	}
+
+	return &session, nil
+}
+
+// Save encrypts and signs session, writing it to the session file.
+func (s *FileSessionStore) Save(session Session) error {
+	plaintext, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("encode session: %w", err)
+	}
+
+	ciphertext, err := s.encrypt(plaintext)
+	if err != nil {
+		return fmt.Errorf("encrypt session: %w", err)
+	}
+
+	tsRaw := []byte(strconv.FormatInt(time.Now().Unix(), 10))
+	sig := s.sign(ciphertext, tsRaw)
+
+	line := strings.Join([]string{
+		base64.StdEncoding.EncodeToString(ciphertext),
+		base64.StdEncoding.EncodeToString(tsRaw),
+		base64.StdEncoding.EncodeToString(sig),
+	}, "|")
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("create session dir: %w", err)
+	}
+
+	return os.WriteFile(s.path, []byte(line), 0o600)
+}
+
+func (s *FileSessionStore) sign(ciphertext, timestamp []byte) []byte {
+	mac := hmac.New(sha256.New, s.key[:])
+	mac.Write(ciphertext)
+	mac.Write(timestamp)
+	return mac.Sum(nil)
+}
+
+func (s *FileSessionStore) encrypt(plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(s.key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext := make([]byte, aes.BlockSize+len(plaintext))
+	iv := ciphertext[:aes.BlockSize]
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, err
+	}
+
+	stream := cipher.NewCFBEncrypter(block, iv)
+	stream.XORKeyStream(ciphertext[aes.BlockSize:], plaintext)
+
+	return ciphertext, nil
+}
+
+func (s *FileSessionStore) decrypt(ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < aes.BlockSize {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	block, err := aes.NewCipher(s.key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	iv := ciphertext[:aes.BlockSize]
+	plaintext := make([]byte, len(ciphertext)-aes.BlockSize)
+	stream := cipher.NewCFBDecrypter(block, iv)
+	stream.XORKeyStream(plaintext, ciphertext[aes.BlockSize:])
+
+	return plaintext, nil
+}
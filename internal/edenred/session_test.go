@@ -0,0 +1,75 @@
+package edenred_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/niklas/edenred-cli/internal/edenred"
+)
+
+func TestFileSessionStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session")
+	store := edenred.NewFileSessionStore(path, []byte("seed"))
+
+	want := edenred.Session{
+		SessionToken: "session-token",
+		RefreshToken: "refresh-token",
+		ExpiresIn:    3600,
+	}
+	if err := store.Save(want); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	got, err := store.Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if got == nil {
+		t.Fatal("expected a cached session, got nil")
+	}
+	if *got != want {
+		t.Fatalf("unexpected session %+v, want %+v", *got, want)
+	}
+}
+
+func TestFileSessionStoreLoadMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session")
+	store := edenred.NewFileSessionStore(path, []byte("seed"))
+
+	got, err := store.Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if got != nil {
+		t.Fatalf("expected no cached session, got %+v", *got)
+	}
+}
+
+func TestFileSessionStoreExpired(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session")
+	store := edenred.NewFileSessionStore(path, []byte("seed"))
+
+	if err := store.Save(edenred.Session{SessionToken: "token", ExpiresIn: -1}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	if _, err := store.Load(); err == nil {
+		t.Fatal("expected expired session to error")
+	}
+}
+
+func TestFileSessionStoreWrongKeyFailsSignatureCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session")
+	writer := edenred.NewFileSessionStore(path, []byte("correct seed"))
+	if err := writer.Save(edenred.Session{SessionToken: "token", ExpiresIn: 3600}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	reader := edenred.NewFileSessionStore(path, []byte("wrong seed"))
+	if _, err := reader.Load(); err == nil {
+		t.Fatal("expected signature mismatch error")
+	} else if !strings.Contains(err.Error(), "signature mismatch") {
+		t.Fatalf("expected signature mismatch error, got %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package edenred
+
+import "time"
+
+// Balances contains the per-wallet balances for a user. Lunch and Virike
+// are kept for backward compatibility with the Finland provider's
+// two-wallet model; Wallets holds every wallet the provider reported,
+// keyed by its provider-specific wallet type, so markets that don't fit
+// the lunch/wellness mould aren't forced into it.
+type Balances struct {
+	Lunch   float64
+	Virike  float64
+	Wallets map[string]Money
+}
+
+// Transaction is a single wallet movement returned by FetchTransactions.
+type Transaction struct {
+	Timestamp  time.Time
+	WalletType string
+	Merchant   string
+	Amount     Money
+	Currency   string
+}
+
+// TransactionOptions filters the transactions returned by
+// FetchTransactions. A zero Since or Until leaves that bound open. Wallet
+// is provider-specific; "" or "all" means every wallet.
+type TransactionOptions struct {
+	Since  time.Time
+	Until  time.Time
+	Wallet string
+}